@@ -0,0 +1,81 @@
+// Package cards provides a reusable representation of a standard deck
+// of playing cards, along with helpers for building and shuffling decks.
+// It was pulled out of the War simulator so other games (Hearts, Kings,
+// Five Crowns, etc.) can share the same card plumbing.
+package cards
+
+import "fmt"
+
+// Suit identifies one of the four standard suits (or NoSuit, for things
+// like jokers that don't belong to one).
+type Suit uint8
+
+const (
+	NoSuit Suit = iota
+	Clubs
+	Diamonds
+	Hearts
+	Spades
+)
+
+func (s Suit) String() string {
+	switch s {
+	case Clubs:
+		return "♣"
+	case Diamonds:
+		return "♦"
+	case Hearts:
+		return "♥"
+	case Spades:
+		return "♠"
+	default:
+		return ""
+	}
+}
+
+// Card packs a face value (2-14, with 11=J, 12=Q, 13=K, 14=Ace) into the
+// low nibble and a Suit into the next three bits. Ace is high, matching
+// the original War simulator's encoding.
+type Card uint8
+
+const (
+	faceMask  = 0x0F
+	suitMask  = 0x70 // 3 bits: NoSuit..Spades is 0-4, which needs more than 2 bits
+	suitShift = 4
+)
+
+// NewCard builds a Card from a face value and a suit.
+func NewCard(face uint8, suit Suit) Card {
+	return Card(face&faceMask) | Card(suit)<<suitShift
+}
+
+// Face returns the numeric face value, 2-14, with Ace high at 14.
+func (c Card) Face() uint8 {
+	return uint8(c) & faceMask
+}
+
+// Suit returns the card's suit.
+func (c Card) Suit() Suit {
+	return Suit((uint8(c) & suitMask) >> suitShift)
+}
+
+// String renders a card like "K♠" or "A♥". Face values above 10 are
+// abbreviated the usual way; jokers (face 0) render as "Jk".
+func (c Card) String() string {
+	var face string
+	switch f := c.Face(); f {
+	case 0:
+		return "Jk"
+	case 11:
+		face = "J"
+	case 12:
+		face = "Q"
+	case 13:
+		face = "K"
+	case 14:
+		face = "A"
+	default:
+		face = fmt.Sprintf("%d", f)
+	}
+	return face + c.Suit().String()
+}
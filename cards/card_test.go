@@ -0,0 +1,35 @@
+package cards
+
+import "testing"
+
+func TestCardRoundTrip(t *testing.T) {
+	suits := []Suit{NoSuit, Clubs, Diamonds, Hearts, Spades}
+	for _, suit := range suits {
+		for _, face := range DefaultFaces {
+			c := NewCard(face, suit)
+			if c.Face() != face {
+				t.Errorf("NewCard(%d, %v).Face() = %d, want %d", face, suit, c.Face(), face)
+			}
+			if c.Suit() != suit {
+				t.Errorf("NewCard(%d, %v).Suit() = %v, want %v", face, suit, c.Suit(), suit)
+			}
+		}
+	}
+}
+
+func TestCardString(t *testing.T) {
+	cases := []struct {
+		card Card
+		want string
+	}{
+		{NewCard(14, Spades), "A♠"},
+		{NewCard(13, Hearts), "K♥"},
+		{NewCard(10, Diamonds), "10♦"},
+		{NewCard(0, NoSuit), "Jk"},
+	}
+	for _, tc := range cases {
+		if got := tc.card.String(); got != tc.want {
+			t.Errorf("String() = %q, want %q", got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package cards
+
+import (
+	rand "github.com/rwtodd/Go.Rand/xoroshiro"
+)
+
+// Deck is just a slice of Cards, dealt or shuffled in place.
+type Deck []Card
+
+// DefaultFaces is the 2..14 (Ace high) range used by a standard deck.
+var DefaultFaces = []uint8{2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14}
+
+// DefaultSuits is the four standard suits, in the order a new deck is built.
+var DefaultSuits = []Suit{Clubs, Diamonds, Hearts, Spades}
+
+// Spec describes the composition of a deck to build: how many copies of
+// a standard deck to combine, which faces and suits to include, and how
+// many (suitless) jokers to add. The zero value is not usable directly;
+// start from StandardSpec and adjust the fields you need.
+type Spec struct {
+	NumDecks      int
+	Faces         []uint8
+	Suits         []Suit
+	JokersPerDeck int
+}
+
+// StandardSpec returns the spec for a single standard 52-card deck.
+func StandardSpec() Spec {
+	return Spec{
+		NumDecks: 1,
+		Faces:    DefaultFaces,
+		Suits:    DefaultSuits,
+	}
+}
+
+// Build constructs the Deck described by the spec, in a fixed
+// (unshuffled) face/suit order, repeated NumDecks times.
+func (s Spec) Build() Deck {
+	perDeck := len(s.Faces)*len(s.Suits) + s.JokersPerDeck
+	deck := make(Deck, 0, perDeck*s.NumDecks)
+	for n := 0; n < s.NumDecks; n++ {
+		for _, suit := range s.Suits {
+			for _, face := range s.Faces {
+				deck = append(deck, NewCard(face, suit))
+			}
+		}
+		for j := 0; j < s.JokersPerDeck; j++ {
+			deck = append(deck, NewCard(0, NoSuit))
+		}
+	}
+	return deck
+}
+
+// NewStandardDeck is a convenience for StandardSpec().Build().
+func NewStandardDeck() Deck {
+	return StandardSpec().Build()
+}
+
+// Shuffle randomizes a Deck in place using the given entropy source, via
+// a Fisher-Yates shuffle.
+func Shuffle(deck Deck, rnd *rand.Rand) {
+	for n := len(deck); n > 1; n-- {
+		randIndex := int(rnd.Int32n(int32(n)))
+		deck[n-1], deck[randIndex] = deck[randIndex], deck[n-1]
+	}
+}
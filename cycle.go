@@ -0,0 +1,75 @@
+package main
+
+import "hash/fnv"
+
+// stateHash hashes the joint state of every player's hand, in player
+// order, using FNV-64a. It's fast and good enough to tell two hand
+// layouts apart for cycle detection -- we don't need cryptographic
+// strength, just low collision odds over a few thousand rounds.
+func stateHash(players []*player) uint64 {
+	h := fnv.New64a()
+	buf := make([]byte, 1)
+	for _, p := range players {
+		for _, c := range p.hand {
+			buf[0] = byte(c)
+			h.Write(buf)
+		}
+		buf[0] = 0xFF // separator between hands
+		h.Write(buf)
+	}
+	return h.Sum64()
+}
+
+// entropyUser is implemented by strategies (like RandomShuffle) whose
+// decisions depend on a PRNG rather than just the game state. For such
+// a strategy, a repeated hand state doesn't imply the game is looping
+// -- the next transition out of that state can still differ -- so
+// cycle detection is unsound and must be skipped.
+type entropyUser interface {
+	UsesEntropy() bool
+}
+
+func anyUsesEntropy(players []*player) bool {
+	for _, p := range players {
+		if eu, ok := p.strategy.(entropyUser); ok && eu.UsesEntropy() {
+			return true
+		}
+	}
+	return false
+}
+
+// cycleDetector watches the joint hand state every CycleCheckEvery
+// rounds and recognizes when a War game has looped back to a state it
+// has already seen -- which some tiebreak strategies can produce, and
+// which would otherwise spin forever.
+type cycleDetector struct {
+	every int
+	seen  map[uint64]int // hash -> round it was first seen
+}
+
+// newCycleDetector returns nil (detection disabled) if CycleCheckEvery
+// is zero, or if any player's Strategy consumes entropy, since a
+// repeated state is only proof of a loop when every transition out of
+// it is deterministic.
+func newCycleDetector(rules Rules, players []*player) *cycleDetector {
+	if rules.CycleCheckEvery <= 0 || anyUsesEntropy(players) {
+		return nil
+	}
+	return &cycleDetector{every: rules.CycleCheckEvery, seen: make(map[uint64]int)}
+}
+
+// check returns the number of rounds since the current state was first
+// seen, or 0 if no repeat was found on this round. Since states are only
+// sampled every cd.every rounds, that is a multiple of the true cycle
+// length rather than necessarily the cycle length itself.
+func (cd *cycleDetector) check(players []*player, round int) int {
+	if cd == nil || round%cd.every != 0 {
+		return 0
+	}
+	h := stateHash(players)
+	if first, ok := cd.seen[h]; ok {
+		return round - first
+	}
+	cd.seen[h] = round
+	return 0
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/rwtodd/Go.WarCards/cards"
+)
+
+func twoHandPlayers(s Strategy) []*player {
+	p0, p1 := newPlayer(s), newPlayer(s)
+	p0.Reset([]cards.Card{cards.NewCard(5, cards.Clubs)})
+	p1.Reset([]cards.Card{cards.NewCard(9, cards.Hearts)})
+	return []*player{p0, p1}
+}
+
+func TestCycleDetectorFindsRepeatedState(t *testing.T) {
+	cd := newCycleDetector(Rules{CycleCheckEvery: 1}, twoHandPlayers(FIFO{}))
+	if cd == nil {
+		t.Fatal("expected cycle detection enabled for a deterministic strategy")
+	}
+
+	players := twoHandPlayers(FIFO{})
+	if length := cd.check(players, 1); length != 0 {
+		t.Fatalf("expected no cycle on first sighting, got length %d", length)
+	}
+	if length := cd.check(players, 5); length != 4 {
+		t.Fatalf("expected a cycle of length 4 when the state repeats, got %d", length)
+	}
+}
+
+func TestCycleDetectorDisabledWhenCheckEveryIsZero(t *testing.T) {
+	if cd := newCycleDetector(Rules{}, twoHandPlayers(FIFO{})); cd != nil {
+		t.Fatal("expected cycle detection disabled when CycleCheckEvery is 0")
+	}
+}
+
+func TestCycleDetectorDisabledForEntropyUsingStrategy(t *testing.T) {
+	players := twoHandPlayers(RandomShuffle{Rnd: gameRand(42)})
+	if cd := newCycleDetector(Rules{CycleCheckEvery: 1}, players); cd != nil {
+		t.Fatal("expected cycle detection disabled when a strategy consumes entropy")
+	}
+}
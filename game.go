@@ -0,0 +1,167 @@
+package main
+
+import "github.com/rwtodd/Go.WarCards/cards"
+
+// Outcome identifies why a game of War ended.
+type Outcome int
+
+const (
+	Won    Outcome = iota // exactly one player still has cards
+	Cutoff                // Rules.MaxRounds was hit before anyone won, or every player ran out of cards at once
+	Cycle                 // the joint hand state repeated; the game would never end
+)
+
+func (o Outcome) String() string {
+	switch o {
+	case Won:
+		return "won"
+	case Cutoff:
+		return "cutoff"
+	case Cycle:
+		return "cycle"
+	default:
+		return "unknown"
+	}
+}
+
+// Rules controls the variant of War being played.
+type Rules struct {
+	// FaceDownPerTie is how many cards each tied player stakes
+	// face-down before drawing their next face-up comparison card.
+	// Zero means "ask the player's Strategy.WarStake instead", which is
+	// how the classic single face-down card per war is expressed now.
+	FaceDownPerTie int
+
+	// MaxRounds caps how many top-level rounds a game can run before
+	// it's declared a Cutoff draw, guarding against shuffles that
+	// produce an endless string of ties. Zero means unlimited.
+	MaxRounds int
+
+	// CycleCheckEvery enables cycle detection, hashing the joint hand
+	// state every this-many rounds and declaring the game a Cycle as
+	// soon as a previously-seen state recurs. Zero disables detection.
+	// Detection is automatically skipped if any player's Strategy
+	// consumes entropy (see entropyUser in cycle.go), since a repeated
+	// state doesn't prove a loop when the next transition out of it can
+	// still differ.
+	CycleCheckEvery int
+}
+
+// stake asks p how many cards to put face-down for a war, honoring
+// FaceDownPerTie first and falling back to the player's own Strategy.
+func (r Rules) stake(p *player) int {
+	if r.FaceDownPerTie > 0 {
+		return r.FaceDownPerTie
+	}
+	return p.strategy.WarStake(p.Hand())
+}
+
+// GameResult reports how a single game of War came out.
+type GameResult struct {
+	Winner      int // index into the players slice, or -1 if there wasn't one
+	Outcome     Outcome
+	Rounds      int
+	CycleLength int // set when Outcome is Cycle: rounds between the two sightings of the repeated state (a multiple of the true cycle length when CycleCheckEvery > 1)
+}
+
+// playGame plays a single game of War among two or more players under
+// the given Rules, returning the winner and why the game ended. Ties
+// recurse into a war among just the tied players; a player who can't
+// make their stake is eliminated from that war (and the game) rather
+// than simply losing it. If trace is non-nil, every draw, tie, and
+// winnings transfer is recorded into it.
+func playGame(players []*player, rules Rules, trace *GameTrace) GameResult {
+	detector := newCycleDetector(rules, players)
+	rounds := 0
+	for numAlive(players) > 1 {
+		if rules.MaxRounds > 0 && rounds >= rules.MaxRounds {
+			return GameResult{Winner: -1, Outcome: Cutoff, Rounds: rounds}
+		}
+		rounds++
+		playRound(players, rules, trace, rounds)
+		if length := detector.check(players, rounds); length > 0 {
+			return GameResult{Winner: -1, Outcome: Cycle, Rounds: rounds, CycleLength: length}
+		}
+	}
+
+	for i, p := range players {
+		if p.Alive() {
+			return GameResult{Winner: i, Outcome: Won, Rounds: rounds}
+		}
+	}
+	return GameResult{Winner: -1, Outcome: Cutoff, Rounds: rounds}
+}
+
+func numAlive(players []*player) int {
+	n := 0
+	for _, p := range players {
+		if p.Alive() {
+			n++
+		}
+	}
+	return n
+}
+
+// playRound plays a single top-level round, including any wars it
+// escalates into, and hands the pot to whoever ends up winning it.
+func playRound(players []*player, rules Rules, trace *GameTrace, round int) {
+	contenders := make([]int, 0, len(players))
+	for i, p := range players {
+		if p.Alive() {
+			contenders = append(contenders, i)
+		}
+	}
+
+	pot := make([]cards.Card, 0, len(contenders)*2)
+	for {
+		faceUp := make(map[int]cards.Card, len(contenders))
+		for _, idx := range contenders {
+			c := players[idx].DrawCard()
+			pot = append(pot, c)
+			faceUp[idx] = c
+			trace.drawn(round, idx, c)
+		}
+
+		best := contenders[0]
+		for _, idx := range contenders[1:] {
+			if faceUp[idx].Face() > faceUp[best].Face() {
+				best = idx
+			}
+		}
+		tied := make([]int, 0, len(contenders))
+		for _, idx := range contenders {
+			if faceUp[idx].Face() == faceUp[best].Face() {
+				tied = append(tied, idx)
+			}
+		}
+		if len(tied) <= 1 {
+			contenders = tied
+			break
+		}
+		trace.tied(round, tied)
+
+		// War: every tied player stakes face-down cards before the
+		// next face-up comparison.
+		next := make([]int, 0, len(tied))
+		for _, idx := range tied {
+			p := players[idx]
+			for i, n := 0, rules.stake(p); i < n && p.Alive(); i++ {
+				pot = append(pot, p.DrawCard())
+			}
+			if p.Alive() {
+				next = append(next, idx)
+			}
+		}
+		contenders = next
+		if len(contenders) == 0 {
+			return // everyone tied ran out of cards at once; the pot is lost
+		}
+	}
+
+	if len(contenders) == 1 {
+		winner := players[contenders[0]]
+		winnings := winner.strategy.OrderWinnings(pot)
+		winner.Accept(winnings)
+		trace.transferred(round, contenders[0], winnings)
+	}
+}
@@ -1,174 +1,181 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"fmt"
+	"math"
+	"os"
+
+	"github.com/rwtodd/Go.WarCards/cards"
 	rand "github.com/rwtodd/Go.Rand/xoroshiro"
 	gorand "math/rand"
 	"time"
 )
 
 // a custom reverse insertion sort... because the built-in one is sloooow.
-func mySort(data []uint8) {
+func mySort(data []cards.Card) {
 	b := len(data)
 	for i := 1; i < b; i++ {
-		for j := i; j > 0 && data[j] > data[j-1]; j-- {
+		for j := i; j > 0 && data[j].Face() > data[j-1].Face(); j-- {
 			data[j], data[j-1] = data[j-1], data[j]
 		}
 	}
 }
 
-// a function to shuffle a []uint8. This is another case where
-// Go falls down for not having genrics.
-func shuffle(vals []uint8, rnd *rand.Rand) {
-	for n := len(vals); n > 0; n-- {
-		randIndex := int(rnd.Int32n(int32(n)))
-		vals[n-1], vals[randIndex] = vals[randIndex], vals[n-1]
-	}
-}
-
-const (
-	qlen = 64 // the length of the queue the players use
-)
-
-// A player is represented by a circular queue of `qlen` cards.
-// I picked 64 to keep the memory aligned.
-type player struct {
-	cards [qlen]uint8 // the cards held
-	rIdx  int         // the read index into cards
-	wIdx  int         // the write index into cards
-}
-
-// Set up the player for then next game. Give them their
-// cards and prepare to start drawing them.
-func (rg *player) Reset(cards []uint8) {
-	rg.wIdx = copy(rg.cards[:], cards)
-	rg.rIdx = 0
-}
-
-// Alive tells if the player has any more cards in hand.
-func (rg *player) Alive() bool {
-	return (rg.rIdx != rg.wIdx)
+// Stats tallies how a batch of games among N strategies came out.
+type Stats struct {
+	Wins         []int // per-player win counts, indexed like the players slice
+	Cutoffs      int   // hit Rules.MaxRounds, or every player ran out at once, with nobody winning
+	Cycles       int   // a repeated hand state was detected
+	CycleLengths []int // length of each detected cycle
 }
 
-// DrawCard pulls the next card from the player's hand, or
-// 0 if there are no more cards.  Zero is chosen because
-// it will always lose.
-func (rg *player) DrawCard() uint8 {
-	if rg.rIdx == rg.wIdx {
-		return 0
-	}
-	answer := rg.cards[rg.rIdx]
-	rg.rIdx++
-	if rg.rIdx == qlen {
-		rg.rIdx = 0
-	}
-	return answer
-}
-
-// Accept adds cards to the hand.
-func (rg *player) Accept(winnings []uint8) {
-	n := copy(rg.cards[rg.wIdx:], winnings)
-	if n < len(winnings) {
-		copy(rg.cards[:], winnings[n:])
-	}
-	rg.wIdx += len(winnings)
-	if rg.wIdx > qlen {
-		rg.wIdx -= qlen
-	}
-}
-
-// makeDeck creates a 52-card deck, with values from
-// 2 to 14, where 11=J, 12=Q, 13=K, 14=Ace since Ace
-// is high in this game.
-func makeDeck() []uint8 {
-	deck := make([]uint8, 52)
-	idx := 0
-	for j := 0; j < 4; j++ {
-		for i := 2; i <= 14; i++ {
-			deck[idx] = uint8(i)
-			idx++
+// add folds a single game's result into the running stats.
+func (s *Stats) add(r GameResult) {
+	switch r.Outcome {
+	case Won:
+		for len(s.Wins) <= r.Winner {
+			s.Wins = append(s.Wins, 0)
 		}
+		s.Wins[r.Winner]++
+	case Cutoff:
+		s.Cutoffs++
+	case Cycle:
+		s.Cycles++
+		s.CycleLengths = append(s.CycleLengths, r.CycleLength)
 	}
-	return deck
 }
 
-// playGame plays a single game of "War" high-card,
-// returning 1 if player 2 won, and 0 otherwise.
-// To avoid threading issues, it has to take the prng
-// as an argument.
-func playGame(p1 *player, p2 *player, rnd *rand.Rand) int {
-	var wins [qlen]uint8
-	for p1.Alive() && p2.Alive() {
-		c1, c2 := p1.DrawCard(), p2.DrawCard()
-		wins[0], wins[1] = c1, c2
-		winIdx := 2
-		for c1 == c2 && p1.Alive() {
-			wins[winIdx], wins[winIdx+1] = p1.DrawCard(), p2.DrawCard()
-			c1, c2 = p1.DrawCard(), p2.DrawCard()
-			wins[winIdx+2], wins[winIdx+3] = c1, c2
-			winIdx = winIdx + 4
-		}
-		winnings := wins[:winIdx]
-		if c1 > c2 {
-			shuffle(winnings, rnd)
-			p1.Accept(winnings)
-		} else {
-			mySort(winnings)
-			p2.Accept(winnings)
+// merge adds another batch's stats into s.
+func (s *Stats) merge(o Stats) {
+	for i, w := range o.Wins {
+		for len(s.Wins) <= i {
+			s.Wins = append(s.Wins, 0)
 		}
+		s.Wins[i] += w
 	}
+	s.Cutoffs += o.Cutoffs
+	s.Cycles += o.Cycles
+	s.CycleLengths = append(s.CycleLengths, o.CycleLengths...)
+}
 
-	if p2.Alive() {
-		return 1
+// played is the total number of completed games the stats represent.
+func (s Stats) played() int {
+	n := s.Cutoffs + s.Cycles
+	for _, w := range s.Wins {
+		n += w
 	}
-	return 0
+	return n
 }
 
-// playN plays `n` games of "War" high-card. It
-// writes the number of times player 2 won to the
-// given `answer` channel.
-func playN(n int, answer chan int) {
-	rnd := rand.New(gorand.Uint64(), gorand.Uint64())
-	deck := makeDeck()
-	p1, p2 := &player{}, &player{}
-	wins := 0
-	for i := 0; i < n; i++ {
-		shuffle(deck, rnd)
-		p1.Reset(deck[:26])
-		p2.Reset(deck[26:])
-		wins += playGame(p1, p2, rnd)
+// winRateCI returns the given player's win rate and a 95% confidence
+// interval around it, using the normal approximation.
+func (s Stats) winRateCI(player int) (rate, lo, hi float64) {
+	n := s.played()
+	if n == 0 || player >= len(s.Wins) {
+		return 0, 0, 0
 	}
-	answer <- wins
+	rate = float64(s.Wins[player]) / float64(n)
+	se := math.Sqrt(rate * (1 - rate) / float64(n))
+	return rate, rate - 1.96*se, rate + 1.96*se
 }
 
 var (
-   nGames = flag.Int("games", 10000, "number of games to play")
-   nProcs = flag.Int("procs", 4, "number of concurrent games to play")
+	nGames          = flag.Int("games", 10000, "number of games to play")
+	nPlayers        = flag.Int("players", 2, "number of players per game (2 or more)")
+	nProcs          = flag.Int("procs", 4, "number of concurrent games to play")
+	batchSize       = flag.Int("batch", 1000, "how many games each worker plays before reporting progress")
+	maxRounds       = flag.Int("maxrounds", 10000, "give up on a game as a cutoff draw after this many rounds (0 = unlimited)")
+	cycleCheckEvery = flag.Int("cyclecheck", 10, "check for a repeated hand state every this-many rounds (0 = disabled)")
+	seed            = flag.Uint64("seed", 0, "master seed for the worker PRNGs (0 = derive one from the clock)")
+	logAnomalies    = flag.Bool("loganomalies", false, "log the seeds of games that cycle, cut off, or end in a shutout")
+	replaySeed      = flag.Uint64("replay", 0, "replay and print the single game this seed produced, instead of running a batch")
 )
+
 func main() {
 	flag.Parse()
 
-	gamesPerCore := *nGames / *nProcs
-	totalGames := gamesPerCore *  *nProcs
+	if *nPlayers < 2 {
+		fmt.Fprintln(os.Stderr, "-players must be 2 or more")
+		os.Exit(1)
+	}
+
+	rules := Rules{MaxRounds: *maxRounds, CycleCheckEvery: *cycleCheckEvery}
 
-	fmt.Printf("Playing %d games each on %d cores.\n", gamesPerCore, *nProcs)
+	if *replaySeed != 0 {
+		if *nPlayers != 2 {
+			fmt.Fprintln(os.Stderr, "-replay only reconstructs the default 2-player matchup; drop -players")
+			os.Exit(1)
+		}
+		fmt.Print(ReplayGame(*replaySeed, rules).Summary())
+		return
+	}
 
-	outputCh := make(chan int, *nProcs)
-	gorand.Seed(time.Now().Unix())
+	masterSeed := *seed
+	if masterSeed == 0 {
+		gorand.Seed(time.Now().Unix())
+		masterSeed = gorand.Uint64()
+	}
 
-	for i := 0; i < *nProcs; i++ {
-		go playN(gamesPerCore, outputCh)
+	fmt.Printf("Playing %d %d-player games across %d workers (seed %d).\n", *nGames, *nPlayers, *nProcs, masterSeed)
+
+	// Seat 0 is the "dumb" random shuffler; every other seat plays the
+	// "smart" descending sort, same as the original 2-player matchup,
+	// just repeated across however many players were asked for.
+	newStrategies := make([]func(rnd *rand.Rand) Strategy, *nPlayers)
+	newStrategies[0] = func(rnd *rand.Rand) Strategy { return RandomShuffle{Rnd: rnd} }
+	for i := 1; i < *nPlayers; i++ {
+		newStrategies[i] = func(rnd *rand.Rand) Strategy { return SortDescending{} }
 	}
 
-	total := 0
-	for i := 0; i < *nProcs; i++ {
-		total += <-outputCh
+	// ReplayGame only knows how to reconstruct the default 2-player
+	// matchup, so logging anomaly seeds from an N-player run would just
+	// point -replay at the wrong game. Refuse to log or advertise them.
+	doLogAnomalies := *logAnomalies
+	if doLogAnomalies && *nPlayers != 2 {
+		fmt.Fprintln(os.Stderr, "note: -loganomalies is only supported for -players=2; not logging anomaly seeds")
+		doLogAnomalies = false
 	}
-	close(outputCh)
 
-	fmt.Printf("Smart player wins: %d games out of %d (%0.2f%%)\n",
-		total,
-		totalGames,
-		(float64(total*100) / float64(totalGames)))
+	sim := Simulator{
+		NewStrategies: newStrategies,
+		Rules:         rules,
+		BatchSize:     *batchSize,
+		Workers:       *nProcs,
+		MasterSeed:    masterSeed,
+		LogAnomalies:  doLogAnomalies,
+	}
+
+	var total Stats
+	var anomalySeeds []uint64
+	for result := range sim.Run(context.Background(), *nGames) {
+		total.merge(result.Stats)
+		anomalySeeds = append(anomalySeeds, result.AnomalySeeds...)
+		if *nPlayers == 2 {
+			rate, lo, hi := total.winRateCI(1)
+			fmt.Printf("%d games played: win rate %.2f%% (95%% CI %.2f%%-%.2f%%)\n",
+				total.played(), rate*100, lo*100, hi*100)
+		} else {
+			fmt.Printf("%d games played\n", total.played())
+		}
+	}
+
+	if *nPlayers == 2 {
+		fmt.Printf("\nSmart player wins: %d games out of %d (%0.2f%%)\n",
+			total.Wins[1], total.played(), float64(total.Wins[1]*100)/float64(total.played()))
+		fmt.Printf("Losses: %d, Cutoffs: %d, Cycles: %d\n", total.Wins[0], total.Cutoffs, total.Cycles)
+	} else {
+		fmt.Println("\nWins per player:")
+		for i, w := range total.Wins {
+			fmt.Printf("  player %d: %d games (%0.2f%%)\n", i, w, float64(w*100)/float64(total.played()))
+		}
+		fmt.Printf("Cutoffs: %d, Cycles: %d\n", total.Cutoffs, total.Cycles)
+	}
+	if len(total.CycleLengths) > 0 {
+		fmt.Printf("Cycle lengths: %v\n", total.CycleLengths)
+	}
+	if len(anomalySeeds) > 0 {
+		fmt.Printf("Anomalous game seeds (replay with -replay=<seed>): %v\n", anomalySeeds)
+	}
 }
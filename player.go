@@ -0,0 +1,65 @@
+package main
+
+import "github.com/rwtodd/Go.WarCards/cards"
+
+// A player holds a hand of cards and the Strategy it uses to order
+// winnings and stake wars. The hand used to be a fixed 64-card ring
+// buffer, but multi-player War can pile up more than that in one hand,
+// so it's just a slice that grows as needed, with drawn cards falling
+// off the front.
+type player struct {
+	hand     []cards.Card
+	strategy Strategy
+}
+
+// newPlayer makes a player that will use the given Strategy.
+func newPlayer(s Strategy) *player {
+	return &player{strategy: s}
+}
+
+// Reset deals the player a fresh hand for the next game.
+func (rg *player) Reset(hand []cards.Card) {
+	rg.hand = append(rg.hand[:0], hand...)
+}
+
+// Alive tells if the player has any more cards in hand.
+func (rg *player) Alive() bool {
+	return len(rg.hand) > 0
+}
+
+// DrawCard pulls the next card from the front of the player's hand, or
+// the zero Card if there are no more cards. The zero Card has face 0,
+// so it will always lose.
+func (rg *player) DrawCard() cards.Card {
+	if len(rg.hand) == 0 {
+		return cards.Card(0)
+	}
+	answer := rg.hand[0]
+	rg.hand = rg.hand[1:]
+	return answer
+}
+
+// Accept adds cards to the back of the hand.
+func (rg *player) Accept(winnings []cards.Card) {
+	rg.hand = append(rg.hand, winnings...)
+}
+
+// Hand returns a snapshot of the cards currently in the player's hand,
+// in draw order.
+func (rg *player) Hand() []cards.Card {
+	snap := make([]cards.Card, len(rg.hand))
+	copy(snap, rg.hand)
+	return snap
+}
+
+// dealHands splits a shuffled deck into n equal-sized contiguous hands,
+// for an N-player game. Any cards left over after an even split (when
+// len(deck) isn't a multiple of n) are left undealt.
+func dealHands(deck cards.Deck, n int) [][]cards.Card {
+	per := len(deck) / n
+	hands := make([][]cards.Card, n)
+	for i := range hands {
+		hands[i] = deck[i*per : (i+1)*per]
+	}
+	return hands
+}
@@ -0,0 +1,163 @@
+package main
+
+import (
+	"context"
+	"sync"
+
+	"github.com/rwtodd/Go.WarCards/cards"
+	rand "github.com/rwtodd/Go.Rand/xoroshiro"
+)
+
+// Result is one batch's worth of outcomes from a Simulator run.
+type Result struct {
+	Stats        Stats
+	GamesPlayed  int      // how many games this batch covered
+	AnomalySeeds []uint64 // seeds of anomalous games, if LogAnomalies was set
+}
+
+// Simulator runs many games of War concurrently with a worker pool: a
+// fixed number of workers pull batches off a job channel, each game's
+// seed is derived straight from MasterSeed and that game's position in
+// the overall run (see splitMix64At), and each finished batch is
+// streamed out as a Result. Deriving seeds from a global game index
+// rather than a per-worker stream means the set of games played for a
+// given MasterSeed doesn't depend on Workers or BatchSize, or on how
+// the job scheduler happens to interleave batches across workers.
+type Simulator struct {
+	// NewStrategies builds a fresh Strategy for each seat in the game,
+	// given that game's own PRNG -- strategies like RandomShuffle need
+	// their own entropy source, since a *rand.Rand isn't safe to share
+	// across goroutines. Its length is the number of players (2 or
+	// more).
+	NewStrategies []func(rnd *rand.Rand) Strategy
+	Rules         Rules
+	BatchSize     int
+	Workers       int
+	MasterSeed    uint64
+
+	// LogAnomalies, if set, has each worker report the seed of every
+	// game that cycled, got cut off, or ended in a lopsided shutout, so
+	// it can be handed to ReplayGame later.
+	LogAnomalies bool
+}
+
+// jobRange is a batch of games to play, identified by the global game
+// indices it covers -- [start, start+count).
+type jobRange struct {
+	start, count int
+}
+
+// Run plays totalGames across the worker pool, returning a channel of
+// per-batch Results. The channel is closed once every batch has been
+// played, or ctx is canceled. Each individual game's seed is a pure
+// function of MasterSeed and its global index in [0, totalGames), so
+// when NewStrategies is the default 2-player pairing (shuffle vs.
+// descending sort), ReplayGame(seed, rules) can reproduce any single
+// game exactly regardless of Workers or BatchSize.
+func (s Simulator) Run(ctx context.Context, totalGames int) <-chan Result {
+	out := make(chan Result, s.Workers)
+	jobs := make(chan jobRange)
+
+	go func() {
+		defer close(jobs)
+		next := 0
+		remaining := totalGames
+		for remaining > 0 {
+			batch := s.BatchSize
+			if batch > remaining {
+				batch = remaining
+			}
+			select {
+			case jobs <- jobRange{start: next, count: batch}:
+				next += batch
+				remaining -= batch
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	var wg sync.WaitGroup
+	for w := 0; w < s.Workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for jr := range jobs {
+				var stats Stats
+				var anomalies []uint64
+				for i := 0; i < jr.count; i++ {
+					gameSeed := splitMix64At(s.MasterSeed, jr.start+i)
+					rnd := gameRand(gameSeed)
+					players := make([]*player, len(s.NewStrategies))
+					for pi, newStrategy := range s.NewStrategies {
+						players[pi] = newPlayer(newStrategy(rnd))
+					}
+					deck := cards.NewStandardDeck()
+					cards.Shuffle(deck, rnd)
+					hands := dealHands(deck, len(players))
+					for pi, p := range players {
+						p.Reset(hands[pi])
+					}
+
+					result := playGame(players, s.Rules, nil)
+					stats.add(result)
+					if s.LogAnomalies && isAnomalous(result) {
+						anomalies = append(anomalies, gameSeed)
+					}
+				}
+				select {
+				case out <- Result{Stats: stats, GamesPlayed: jr.count, AnomalySeeds: anomalies}:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+	}
+
+	go func() {
+		wg.Wait()
+		close(out)
+	}()
+
+	return out
+}
+
+// splitMix64Increment is the constant SplitMix64 adds to its state on
+// every step.
+const splitMix64Increment = 0x9E3779B97F4A7C15
+
+// splitMix64Next advances state and returns the next SplitMix64 word.
+func splitMix64Next(state *uint64) uint64 {
+	*state += splitMix64Increment
+	return splitMix64Mix(*state)
+}
+
+// splitMix64Mix is SplitMix64's output mixing function.
+func splitMix64Mix(z uint64) uint64 {
+	z = (z ^ (z >> 30)) * 0xBF58476D1CE4E5B9
+	z = (z ^ (z >> 27)) * 0x94D049BB133111EB
+	return z ^ (z >> 31)
+}
+
+// splitMix64At returns the SplitMix64 word at a given zero-based index
+// in the stream seeded by master, without needing to replay every
+// earlier step: advancing the state n times is just adding n copies of
+// the increment, so index i's state is master + (i+1)*increment. This
+// lets every game's seed be derived straight from its global index,
+// independent of which worker plays it or in what order.
+func splitMix64At(master uint64, index int) uint64 {
+	return splitMix64Mix(master + uint64(index+1)*splitMix64Increment)
+}
+
+// splitMix64Seeds derives n deterministic words from a single master
+// seed, using SplitMix64 -- the usual way to turn one seed into several
+// independent streams (one per worker, or the two xoroshiro words a
+// single game needs) without those streams overlapping.
+func splitMix64Seeds(master uint64, n int) []uint64 {
+	state := master
+	seeds := make([]uint64, n)
+	for i := range seeds {
+		seeds[i] = splitMix64Next(&state)
+	}
+	return seeds
+}
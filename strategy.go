@@ -0,0 +1,83 @@
+package main
+
+import (
+	"github.com/rwtodd/Go.WarCards/cards"
+	rand "github.com/rwtodd/Go.Rand/xoroshiro"
+)
+
+// Strategy controls how a player behaves during a game of War: how they
+// reorder the cards they win before stashing them at the bottom of their
+// hand, and how many cards they stake face-down when a war breaks out.
+type Strategy interface {
+	OrderWinnings(won []cards.Card) []cards.Card
+	WarStake(hand []cards.Card) int
+}
+
+// RandomShuffle shuffles winnings into a random order. This was player
+// 1's only behavior before strategies existed.
+type RandomShuffle struct {
+	Rnd *rand.Rand
+}
+
+func (s RandomShuffle) OrderWinnings(won []cards.Card) []cards.Card {
+	cards.Shuffle(cards.Deck(won), s.Rnd)
+	return won
+}
+
+func (s RandomShuffle) WarStake(hand []cards.Card) int { return 1 }
+
+// UsesEntropy reports that RandomShuffle's choices depend on its PRNG,
+// not just the game state -- see entropyUser in cycle.go.
+func (s RandomShuffle) UsesEntropy() bool { return true }
+
+// SortDescending sorts winnings highest-first, so the biggest cards come
+// back around soonest. This was player 2's only behavior before
+// strategies existed.
+type SortDescending struct{}
+
+func (SortDescending) OrderWinnings(won []cards.Card) []cards.Card {
+	mySort(won)
+	return won
+}
+
+func (SortDescending) WarStake(hand []cards.Card) int { return 1 }
+
+// SortAscending sorts winnings lowest-first, so the biggest cards end up
+// buried at the bottom of the hand.
+type SortAscending struct{}
+
+func (SortAscending) OrderWinnings(won []cards.Card) []cards.Card {
+	mySort(won)
+	for i, j := 0, len(won)-1; i < j; i, j = i+1, j-1 {
+		won[i], won[j] = won[j], won[i]
+	}
+	return won
+}
+
+func (SortAscending) WarStake(hand []cards.Card) int { return 1 }
+
+// KeepHighOnTop moves the single highest card in the winnings to the
+// front, leaving the rest in the order they were collected.
+type KeepHighOnTop struct{}
+
+func (KeepHighOnTop) OrderWinnings(won []cards.Card) []cards.Card {
+	hi := 0
+	for i := 1; i < len(won); i++ {
+		if won[i].Face() > won[hi].Face() {
+			hi = i
+		}
+	}
+	top := won[hi]
+	copy(won[1:hi+1], won[:hi])
+	won[0] = top
+	return won
+}
+
+func (KeepHighOnTop) WarStake(hand []cards.Card) int { return 1 }
+
+// FIFO leaves winnings in the order they were collected.
+type FIFO struct{}
+
+func (FIFO) OrderWinnings(won []cards.Card) []cards.Card { return won }
+
+func (FIFO) WarStake(hand []cards.Card) int { return 1 }
@@ -0,0 +1,130 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rwtodd/Go.WarCards/cards"
+	rand "github.com/rwtodd/Go.Rand/xoroshiro"
+)
+
+func TestStrategyOrderWinnings(t *testing.T) {
+	won := func() []cards.Card {
+		return []cards.Card{
+			cards.NewCard(5, cards.Clubs),
+			cards.NewCard(14, cards.Hearts),
+			cards.NewCard(9, cards.Diamonds),
+		}
+	}
+
+	cases := []struct {
+		name     string
+		strategy Strategy
+		want     []int // expected faces, in order
+	}{
+		{"SortDescending", SortDescending{}, []int{14, 9, 5}},
+		{"SortAscending", SortAscending{}, []int{5, 9, 14}},
+		{"KeepHighOnTop", KeepHighOnTop{}, []int{14, 5, 9}},
+		{"FIFO", FIFO{}, []int{5, 14, 9}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ordered := tc.strategy.OrderWinnings(won())
+			if len(ordered) != len(tc.want) {
+				t.Fatalf("got %d cards, want %d", len(ordered), len(tc.want))
+			}
+			for i, face := range tc.want {
+				if int(ordered[i].Face()) != face {
+					t.Errorf("position %d: got face %d, want %d", i, ordered[i].Face(), face)
+				}
+			}
+		})
+	}
+}
+
+func TestPlayGameDeterministicWinner(t *testing.T) {
+	p0 := newPlayer(FIFO{})
+	p0.Reset([]cards.Card{cards.NewCard(14, cards.Spades)})
+	p1 := newPlayer(FIFO{})
+	p1.Reset([]cards.Card{cards.NewCard(5, cards.Clubs)})
+
+	result := playGame([]*player{p0, p1}, Rules{}, nil)
+
+	if result.Outcome != Won {
+		t.Fatalf("got outcome %s, want %s", result.Outcome, Won)
+	}
+	if result.Winner != 0 {
+		t.Fatalf("got winner %d, want 0", result.Winner)
+	}
+	if result.Rounds != 1 {
+		t.Fatalf("got %d rounds, want 1", result.Rounds)
+	}
+}
+
+// TestSimulatorTournament exercises the Simulator as the reusable
+// strategy-comparison harness it's meant to be: the set of games played
+// for a given MasterSeed must be independent of Workers and BatchSize,
+// since those just control how the work is scheduled, not which games
+// get played. Vary both across runs and confirm identical,
+// internally-consistent per-strategy tallies every time.
+func TestSimulatorTournament(t *testing.T) {
+	const games = 200
+	newStrategies := []func(rnd *rand.Rand) Strategy{
+		func(rnd *rand.Rand) Strategy { return SortDescending{} },
+		func(rnd *rand.Rand) Strategy { return SortAscending{} },
+	}
+
+	run := func(workers, batchSize int) Stats {
+		sim := Simulator{
+			NewStrategies: newStrategies,
+			Rules:         Rules{MaxRounds: 5000, CycleCheckEvery: 10},
+			BatchSize:     batchSize,
+			Workers:       workers,
+			MasterSeed:    99,
+		}
+		var total Stats
+		for result := range sim.Run(context.Background(), games) {
+			total.merge(result.Stats)
+		}
+		return total
+	}
+
+	configs := []struct{ workers, batchSize int }{
+		{1, games},
+		{2, 10},
+		{3, 7},
+		{4, 1},
+	}
+
+	var want Stats
+	for i, cfg := range configs {
+		got := run(cfg.workers, cfg.batchSize)
+		if got.played() != games {
+			t.Fatalf("workers=%d batch=%d: got %d games played, want %d", cfg.workers, cfg.batchSize, got.played(), games)
+		}
+		sum := got.Cutoffs + got.Cycles
+		for _, w := range got.Wins {
+			sum += w
+		}
+		if sum != games {
+			t.Fatalf("workers=%d batch=%d: wins+cutoffs+cycles = %d, want %d", cfg.workers, cfg.batchSize, sum, games)
+		}
+
+		if i == 0 {
+			want = got
+			continue
+		}
+		if len(want.Wins) != len(got.Wins) {
+			t.Fatalf("workers=%d batch=%d: got %v wins, want %v", cfg.workers, cfg.batchSize, got.Wins, want.Wins)
+		}
+		for p := range want.Wins {
+			if want.Wins[p] != got.Wins[p] {
+				t.Errorf("workers=%d batch=%d: player %d got %d wins, want %d (same MasterSeed as workers=%d batch=%d)",
+					cfg.workers, cfg.batchSize, p, got.Wins[p], want.Wins[p], configs[0].workers, configs[0].batchSize)
+			}
+		}
+		if want.Cutoffs != got.Cutoffs || want.Cycles != got.Cycles {
+			t.Errorf("workers=%d batch=%d: got %+v, want %+v", cfg.workers, cfg.batchSize, got, want)
+		}
+	}
+}
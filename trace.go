@@ -0,0 +1,137 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/rwtodd/Go.WarCards/cards"
+	rand "github.com/rwtodd/Go.Rand/xoroshiro"
+)
+
+// MoveKind identifies what a single Move in a GameTrace records.
+type MoveKind int
+
+const (
+	MoveDraw     MoveKind = iota // a player drew a face-up comparison card
+	MoveTie                      // two or more players tied and a war started
+	MoveTransfer                 // a player's winnings were added to their hand
+)
+
+func (k MoveKind) String() string {
+	switch k {
+	case MoveDraw:
+		return "draw"
+	case MoveTie:
+		return "tie"
+	case MoveTransfer:
+		return "transfer"
+	default:
+		return "unknown"
+	}
+}
+
+// Move is a single recorded event within a GameTrace.
+type Move struct {
+	Round  int
+	Kind   MoveKind
+	Player int          // which player this move concerns
+	Card   cards.Card   // the card drawn, for a MoveDraw
+	Cards  []cards.Card // the cards transferred, for a MoveTransfer
+}
+
+// GameTrace is the full move-by-move record of one game, plus the seed
+// that produced it, so the game can be replayed and inspected later.
+type GameTrace struct {
+	Seed   uint64
+	Moves  []Move
+	Result GameResult
+}
+
+func (t *GameTrace) drawn(round, player int, c cards.Card) {
+	if t == nil {
+		return
+	}
+	t.Moves = append(t.Moves, Move{Round: round, Kind: MoveDraw, Player: player, Card: c})
+}
+
+func (t *GameTrace) tied(round int, players []int) {
+	if t == nil {
+		return
+	}
+	for _, p := range players {
+		t.Moves = append(t.Moves, Move{Round: round, Kind: MoveTie, Player: p})
+	}
+}
+
+func (t *GameTrace) transferred(round, player int, winnings []cards.Card) {
+	if t == nil {
+		return
+	}
+	cp := make([]cards.Card, len(winnings))
+	copy(cp, winnings)
+	t.Moves = append(t.Moves, Move{Round: round, Kind: MoveTransfer, Player: player, Cards: cp})
+}
+
+// gameRand builds the deterministic PRNG for a single game from one
+// uint64 seed, via SplitMix64 -- the same derivation a Simulator worker
+// uses, so a seed logged during a batch run reproduces exactly here.
+func gameRand(seed uint64) *rand.Rand {
+	words := splitMix64Seeds(seed, 2)
+	return rand.New(words[0], words[1])
+}
+
+// ReplayGame deterministically replays the single game that `seed`
+// produces under `rules`, against the simulator's default 2-player
+// strategy pairing (shuffle vs. descending sort), and returns its full
+// move list so an anomalous game can be examined without rerunning a
+// batch. It doesn't know how many players an N-player run used, so a
+// seed logged from a -players=3-or-more run can't be replayed with it.
+func ReplayGame(seed uint64, rules Rules) GameTrace {
+	rnd := gameRand(seed)
+	players := []*player{
+		newPlayer(RandomShuffle{Rnd: rnd}),
+		newPlayer(SortDescending{}),
+	}
+	deck := cards.NewStandardDeck()
+	cards.Shuffle(deck, rnd)
+	hands := dealHands(deck, len(players))
+	players[0].Reset(hands[0])
+	players[1].Reset(hands[1])
+
+	trace := &GameTrace{Seed: seed}
+	trace.Result = playGame(players, rules, trace)
+	return *trace
+}
+
+// anomalyRoundsThreshold: a Won game finishing in this few rounds or
+// fewer is considered a lopsided shutout worth logging.
+const anomalyRoundsThreshold = 3
+
+// isAnomalous reports whether a game's result is interesting enough to
+// log its seed for later replay: it cycled, got cut off, or ended in a
+// lopsided shutout.
+func isAnomalous(r GameResult) bool {
+	switch r.Outcome {
+	case Cycle, Cutoff:
+		return true
+	case Won:
+		return r.Rounds <= anomalyRoundsThreshold
+	default:
+		return false
+	}
+}
+
+// Summary renders a GameTrace as a human-readable move list.
+func (t GameTrace) Summary() string {
+	s := fmt.Sprintf("seed %d, outcome %s after %d rounds:\n", t.Seed, t.Result.Outcome, t.Result.Rounds)
+	for _, m := range t.Moves {
+		switch m.Kind {
+		case MoveDraw:
+			s += fmt.Sprintf("  round %d: player %d drew %s\n", m.Round, m.Player, m.Card)
+		case MoveTie:
+			s += fmt.Sprintf("  round %d: player %d tied, going to war\n", m.Round, m.Player)
+		case MoveTransfer:
+			s += fmt.Sprintf("  round %d: player %d collected %d cards\n", m.Round, m.Player, len(m.Cards))
+		}
+	}
+	return s
+}